@@ -0,0 +1,136 @@
+// conf.go - pacman.conf repository configuration.
+//
+// Copyright (c) 2013 The go-alpm Authors
+//
+// MIT Licensed. See LICENSE for details.
+
+package alpm
+
+// #cgo CFLAGS: -D_FILE_OFFSET_BITS=64
+// #include <alpm.h>
+import "C"
+
+import (
+	"strings"
+	"unsafe"
+)
+
+// RepoConfig describes a single `[repo]` section of pacman.conf.
+type RepoConfig struct {
+	Name    string
+	Servers []string
+	Usage   Usage
+}
+
+// ConfSection holds the raw "key = value" lines of a single `[repo]`
+// section of pacman.conf, in file order.
+type ConfSection struct {
+	Name  string
+	Lines []string
+}
+
+// parseRepoDirective updates repo in place for a single "key = value" line
+// found inside its pacman.conf section. Only the "Usage" directive is
+// handled here; callers dispatch other keys (Server, SigLevel, ...)
+// themselves.
+func parseRepoDirective(repo *RepoConfig, key, value string) error {
+	switch key {
+	case "Usage":
+		usage, err := parseUsage(strings.Fields(value))
+		if err != nil {
+			return err
+		}
+
+		repo.Usage = usage
+	}
+
+	return nil
+}
+
+// newCStringList builds a C alpm_list_t of strdup'd strings from strs.
+// Ownership of the returned list and its contents passes to the callee.
+func newCStringList(strs []string) *C.alpm_list_t {
+	var list *C.alpm_list_t
+
+	for _, s := range strs {
+		list = C.alpm_list_add(list, unsafe.Pointer(C.CString(s)))
+	}
+
+	return list
+}
+
+// registerSyncDB registers repo as a sync database on h and applies its
+// parsed Usage directive and server list.
+func registerSyncDB(h *Handle, repo RepoConfig) (DB, error) {
+	db, err := h.RegisterSyncDB(repo.Name, 0)
+	if err != nil {
+		return DB{}, err
+	}
+
+	if repo.Usage != 0 {
+		if err := db.SetUsage(repo.Usage); err != nil {
+			return DB{}, err
+		}
+	}
+
+	if len(repo.Servers) > 0 {
+		if C.alpm_db_set_servers(db.ptr, newCStringList(repo.Servers)) != 0 {
+			return DB{}, db.handle.LastError()
+		}
+	}
+
+	return db, nil
+}
+
+// splitIniDirective splits a "key = value" pacman.conf line, trimming
+// whitespace around the key, the `=`, and the value.
+func splitIniDirective(line string) (key, value string, ok bool) {
+	idx := strings.IndexByte(line, '=')
+	if idx == -1 {
+		return "", "", false
+	}
+
+	key = strings.TrimSpace(line[:idx])
+	value = strings.TrimSpace(line[idx+1:])
+
+	return key, value, key != ""
+}
+
+// CreateHandle initializes a Handle rooted at root/dbpath and registers a
+// sync database for each `[repo]` section in repoSections, in the order
+// given — pacman.conf's repo order determines sync-db priority, so it must
+// be preserved rather than read from an unordered map. Each section's
+// "Server" and "Usage" directives (the latter via parseRepoDirective) are
+// applied to the resulting DB.
+func CreateHandle(root, dbpath string, repoSections []ConfSection) (*Handle, error) {
+	h, err := Init(root, dbpath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, section := range repoSections {
+		repo := RepoConfig{Name: section.Name}
+
+		for _, line := range section.Lines {
+			key, value, ok := splitIniDirective(line)
+			if !ok {
+				continue
+			}
+
+			if key == "Server" {
+				repo.Servers = append(repo.Servers, value)
+				continue
+			}
+
+			if err := parseRepoDirective(&repo, key, value); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := registerSyncDB(h, repo); err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
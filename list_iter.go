@@ -0,0 +1,43 @@
+// list_iter.go - range-over-func iterators for the remaining list wrappers.
+//
+// Copyright (c) 2013 The go-alpm Authors
+//
+// MIT Licensed. See LICENSE for details.
+
+package alpm
+
+// #cgo CFLAGS: -D_FILE_OFFSET_BITS=64
+// #include <alpm.h>
+import "C"
+
+import (
+	"iter"
+	"unsafe"
+)
+
+// All returns an iterator over the list's packages, for use with range-over-func.
+func (l PackageList) All() iter.Seq[Package] {
+	return func(yield func(Package) bool) {
+		l.list.all(func(p unsafe.Pointer) bool {
+			return yield(Package{(*C.alpm_pkg_t)(p), l.handle})
+		})
+	}
+}
+
+// All returns an iterator over the list's dependencies, for use with range-over-func.
+func (l DependList) All() iter.Seq[Depend] {
+	return func(yield func(Depend) bool) {
+		l.list.all(func(p unsafe.Pointer) bool {
+			return yield(convertDepend((*C.alpm_depend_t)(p)))
+		})
+	}
+}
+
+// All returns an iterator over the list's databases, for use with range-over-func.
+func (l DBList) All() iter.Seq[DB] {
+	return func(yield func(DB) bool) {
+		l.list.all(func(p unsafe.Pointer) bool {
+			return yield(DB{(*C.alpm_db_t)(p), l.handle})
+		})
+	}
+}
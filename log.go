@@ -0,0 +1,87 @@
+// log.go - libalpm log callback.
+//
+// Copyright (c) 2013 The go-alpm Authors
+//
+// MIT Licensed. See LICENSE for details.
+
+package alpm
+
+// #cgo CFLAGS: -D_FILE_OFFSET_BITS=64
+// #include <stdarg.h>
+// #include <stdio.h>
+// #include <alpm.h>
+//
+// extern void logCallbackCgo(void *ctx, alpm_loglevel_t level, char *fmt);
+//
+// static void logCallbackTrampoline(void *ctx, alpm_loglevel_t level, const char *fmt, va_list args) {
+//   char *msg = NULL;
+//   vasprintf(&msg, fmt, args);
+//   logCallbackCgo(ctx, level, msg);
+// }
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// LogLevel represents the severity of a message reported through a
+// LogCallback, mirroring alpm_loglevel_t.
+type LogLevel int
+
+const (
+	LogError    LogLevel = C.ALPM_LOG_ERROR
+	LogWarning  LogLevel = C.ALPM_LOG_WARNING
+	LogDebug    LogLevel = C.ALPM_LOG_DEBUG
+	LogFunction LogLevel = C.ALPM_LOG_FUNCTION
+)
+
+// LogCallback is called by libalpm whenever it wants to report a message.
+type LogCallback func(LogLevel, string)
+
+var (
+	logCallbacksMu sync.Mutex
+	logCallbacks   = map[uintptr]LogCallback{}
+)
+
+// SetLogCallback registers cb to be invoked for every message libalpm logs
+// on this handle. Pass nil to unset a previously registered callback.
+func (h *Handle) SetLogCallback(cb LogCallback) error {
+	key := uintptr(unsafe.Pointer(h.ptr))
+
+	logCallbacksMu.Lock()
+	if cb == nil {
+		delete(logCallbacks, key)
+	} else {
+		logCallbacks[key] = cb
+	}
+	logCallbacksMu.Unlock()
+
+	if cb == nil {
+		if C.alpm_option_set_logcb(h.ptr, nil, nil) != 0 {
+			return h.LastError()
+		}
+		return nil
+	}
+
+	if C.alpm_option_set_logcb(h.ptr, C.alpm_cb_log(C.logCallbackTrampoline), unsafe.Pointer(h.ptr)) != 0 {
+		return h.LastError()
+	}
+
+	return nil
+}
+
+//export logCallbackCgo
+func logCallbackCgo(ctx unsafe.Pointer, level C.alpm_loglevel_t, msg *C.char) {
+	defer C.free(unsafe.Pointer(msg))
+
+	logCallbacksMu.Lock()
+	cb, ok := logCallbacks[uintptr(ctx)]
+	logCallbacksMu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	cb(LogLevel(level), C.GoString(msg))
+}
@@ -0,0 +1,274 @@
+// parse.go - .SRCINFO line-oriented parser.
+//
+// Copyright (c) 2013 The go-alpm Authors
+//
+// MIT Licensed. See LICENSE for details.
+
+package srcinfo
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/MadcowOG/go-alpm"
+)
+
+// ParseSrcinfoFile reads and parses the .SRCINFO file at path.
+func ParseSrcinfoFile(path string) (*Srcinfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseSrcinfo(f)
+}
+
+// ParseSrcinfo parses the .SRCINFO contents read from r.
+func ParseSrcinfo(r io.Reader) (*Srcinfo, error) {
+	info := &Srcinfo{
+		PkgBase:  newPackage(),
+		Packages: map[string]Package{},
+	}
+
+	var currentName string
+	current := &info.PkgBase
+
+	// seen tracks, per pkgname section, which fields have already been
+	// written in that section so the first write can override the
+	// inherited pkgbase value instead of merging with it. nil while
+	// parsing the pkgbase section itself, where repeated keys accumulate.
+	var seen map[string]bool
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := splitDirective(line)
+		if !ok {
+			continue
+		}
+
+		field, arch := splitArch(key)
+
+		switch field {
+		case "pkgbase":
+			info.PkgBase.Pkgname = value
+			current = &info.PkgBase
+			seen = nil
+			continue
+		case "pkgname":
+			pkg := clone(info.PkgBase)
+			pkg.Pkgname = value
+			currentName = value
+			info.Packages[currentName] = pkg
+			current = nil
+			seen = map[string]bool{}
+			continue
+		}
+
+		if current == nil {
+			// We're inside a pkgname section; mutate a local copy and
+			// write it back since map values aren't addressable.
+			pkg := info.Packages[currentName]
+			if err := setField(&pkg, field, arch, value, seen); err != nil {
+				return nil, err
+			}
+			info.Packages[currentName] = pkg
+
+			continue
+		}
+
+		if err := setField(current, field, arch, value, seen); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// splitDirective splits a "key = value" line, trimming whitespace around
+// the key, the `=`, and the value.
+func splitDirective(line string) (key, value string, ok bool) {
+	idx := strings.IndexByte(line, '=')
+	if idx == -1 {
+		return "", "", false
+	}
+
+	key = strings.TrimSpace(line[:idx])
+	value = strings.TrimSpace(line[idx+1:])
+
+	return key, value, key != ""
+}
+
+// splitArch splits an architecture-qualified key such as "depends_x86_64"
+// into its field name ("depends") and arch ("x86_64"). Keys without an
+// arch suffix are returned with an empty arch.
+func splitArch(key string) (field, arch string) {
+	if idx := strings.IndexByte(key, '_'); idx != -1 {
+		if _, ok := archFields[key[:idx]]; ok {
+			return key[:idx], key[idx+1:]
+		}
+	}
+
+	return key, ""
+}
+
+var archFields = map[string]bool{
+	"depends":      true,
+	"makedepends":  true,
+	"checkdepends": true,
+	"optdepends":   true,
+	"provides":     true,
+	"conflicts":    true,
+	"replaces":     true,
+	"source":       true,
+	"md5sums":      true,
+	"sha1sums":     true,
+	"sha256sums":   true,
+	"sha512sums":   true,
+	"b2sums":       true,
+}
+
+// firstInSection reports whether key is being written for the first time
+// in the current pkgname section. seen is nil while parsing the pkgbase
+// section, where repeated keys always accumulate rather than override.
+func firstInSection(seen map[string]bool, key string) bool {
+	if seen == nil || seen[key] {
+		return false
+	}
+
+	seen[key] = true
+
+	return true
+}
+
+func setField(pkg *Package, field, arch, value string, seen map[string]bool) error {
+	switch field {
+	case "pkgver":
+		pkg.Pkgver = value
+	case "pkgrel":
+		pkg.Pkgrel = value
+	case "epoch":
+		pkg.Epoch = value
+	case "pkgdesc":
+		pkg.Pkgdesc = value
+	case "url":
+		pkg.URL = value
+	case "install":
+		pkg.Install = value
+	case "changelog":
+		pkg.Changelog = value
+	case "arch":
+		if firstInSection(seen, field) {
+			pkg.Arch = nil
+		}
+		pkg.Arch = append(pkg.Arch, value)
+	case "license":
+		if firstInSection(seen, field) {
+			pkg.License = nil
+		}
+		pkg.License = append(pkg.License, value)
+	case "groups":
+		if firstInSection(seen, field) {
+			pkg.Groups = nil
+		}
+		pkg.Groups = append(pkg.Groups, value)
+	case "noextract":
+		if firstInSection(seen, field) {
+			pkg.NoExtract = nil
+		}
+		pkg.NoExtract = append(pkg.NoExtract, value)
+	case "options":
+		if firstInSection(seen, field) {
+			pkg.Options = nil
+		}
+		pkg.Options = append(pkg.Options, value)
+	case "backup":
+		if firstInSection(seen, field) {
+			pkg.Backup = nil
+		}
+		pkg.Backup = append(pkg.Backup, value)
+	case "validpgpkeys":
+		if firstInSection(seen, field) {
+			pkg.ValidPGPKeys = nil
+		}
+		pkg.ValidPGPKeys = append(pkg.ValidPGPKeys, value)
+	case "source":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.Source[arch] = nil
+		}
+		pkg.Source[arch] = append(pkg.Source[arch], value)
+	case "md5sums":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.Md5Sums[arch] = nil
+		}
+		pkg.Md5Sums[arch] = append(pkg.Md5Sums[arch], value)
+	case "sha1sums":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.Sha1Sums[arch] = nil
+		}
+		pkg.Sha1Sums[arch] = append(pkg.Sha1Sums[arch], value)
+	case "sha256sums":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.Sha256Sums[arch] = nil
+		}
+		pkg.Sha256Sums[arch] = append(pkg.Sha256Sums[arch], value)
+	case "sha512sums":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.Sha512Sums[arch] = nil
+		}
+		pkg.Sha512Sums[arch] = append(pkg.Sha512Sums[arch], value)
+	case "b2sums":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.B2Sums[arch] = nil
+		}
+		pkg.B2Sums[arch] = append(pkg.B2Sums[arch], value)
+	case "depends":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.Depends[arch] = nil
+		}
+		pkg.Depends[arch] = append(pkg.Depends[arch], alpm.ParseDepend(value))
+	case "makedepends":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.MakeDepends[arch] = nil
+		}
+		pkg.MakeDepends[arch] = append(pkg.MakeDepends[arch], alpm.ParseDepend(value))
+	case "checkdepends":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.CheckDepends[arch] = nil
+		}
+		pkg.CheckDepends[arch] = append(pkg.CheckDepends[arch], alpm.ParseDepend(value))
+	case "optdepends":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.OptDepends[arch] = nil
+		}
+		pkg.OptDepends[arch] = append(pkg.OptDepends[arch], alpm.ParseDepend(value))
+	case "provides":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.Provides[arch] = nil
+		}
+		pkg.Provides[arch] = append(pkg.Provides[arch], alpm.ParseDepend(value))
+	case "conflicts":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.Conflicts[arch] = nil
+		}
+		pkg.Conflicts[arch] = append(pkg.Conflicts[arch], alpm.ParseDepend(value))
+	case "replaces":
+		if firstInSection(seen, field+"_"+arch) {
+			pkg.Replaces[arch] = nil
+		}
+		pkg.Replaces[arch] = append(pkg.Replaces[arch], alpm.ParseDepend(value))
+	}
+
+	return nil
+}
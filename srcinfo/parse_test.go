@@ -0,0 +1,121 @@
+// parse_test.go - .SRCINFO parser tests.
+//
+// Copyright (c) 2013 The go-alpm Authors
+//
+// MIT Licensed. See LICENSE for details.
+
+package srcinfo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MadcowOG/go-alpm"
+)
+
+func TestParseSrcinfoCommentsAndBlankLines(t *testing.T) {
+	const src = `
+# this is a comment
+pkgbase = foo
+
+	# indented comment
+pkgver = 1.0
+pkgrel = 1
+
+pkgname = foo
+`
+
+	info, err := ParseSrcinfo(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseSrcinfo: %v", err)
+	}
+
+	if info.PkgBase.Pkgname != "foo" {
+		t.Errorf("PkgBase.Pkgname = %q, want %q", info.PkgBase.Pkgname, "foo")
+	}
+
+	if info.PkgBase.Pkgver != "1.0" {
+		t.Errorf("PkgBase.Pkgver = %q, want %q", info.PkgBase.Pkgver, "1.0")
+	}
+
+	if info.PkgBase.Pkgrel != "1" {
+		t.Errorf("PkgBase.Pkgrel = %q, want %q", info.PkgBase.Pkgrel, "1")
+	}
+
+	if _, ok := info.Packages["foo"]; !ok {
+		t.Errorf("Packages missing %q", "foo")
+	}
+}
+
+func TestParseSrcinfoArchQualifiedDepends(t *testing.T) {
+	const src = `
+pkgbase = foo
+pkgver = 1.0
+pkgrel = 1
+depends = foo-common
+depends_x86_64 = foo-x86
+
+pkgname = foo
+`
+
+	info, err := ParseSrcinfo(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseSrcinfo: %v", err)
+	}
+
+	pkg := info.Packages["foo"]
+
+	want := map[string][]alpm.Depend{
+		"":       {alpm.ParseDepend("foo-common")},
+		"x86_64": {alpm.ParseDepend("foo-x86")},
+	}
+
+	if !reflect.DeepEqual(pkg.Depends, want) {
+		t.Errorf("Depends = %#v, want %#v", pkg.Depends, want)
+	}
+}
+
+func TestParseSrcinfoPackageOverridesArrayField(t *testing.T) {
+	const src = `
+pkgbase = foo
+pkgver = 1.0
+pkgrel = 1
+depends = foo-common
+arch = x86_64
+
+pkgname = foo
+depends = foo-core
+
+pkgname = bar
+`
+
+	info, err := ParseSrcinfo(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseSrcinfo: %v", err)
+	}
+
+	foo := info.Packages["foo"]
+	wantFoo := []alpm.Depend{alpm.ParseDepend("foo-core")}
+	if !reflect.DeepEqual(foo.Depends[""], wantFoo) {
+		t.Errorf("foo.Depends[\"\"] = %#v, want %#v (package-level depends should override, not merge with, pkgbase)", foo.Depends[""], wantFoo)
+	}
+
+	// bar never re-declares depends, so it should inherit pkgbase's value
+	// unmodified.
+	bar := info.Packages["bar"]
+	wantBar := []alpm.Depend{alpm.ParseDepend("foo-common")}
+	if !reflect.DeepEqual(bar.Depends[""], wantBar) {
+		t.Errorf("bar.Depends[\"\"] = %#v, want %#v (unmodified pkgbase inheritance)", bar.Depends[""], wantBar)
+	}
+
+	// arch was never re-declared by either package, so both should inherit
+	// the pkgbase value.
+	wantArch := []string{"x86_64"}
+	if !reflect.DeepEqual(foo.Arch, wantArch) {
+		t.Errorf("foo.Arch = %#v, want %#v", foo.Arch, wantArch)
+	}
+	if !reflect.DeepEqual(bar.Arch, wantArch) {
+		t.Errorf("bar.Arch = %#v, want %#v", bar.Arch, wantArch)
+	}
+}
@@ -0,0 +1,126 @@
+// srcinfo.go - structured representation of a .SRCINFO file.
+//
+// Copyright (c) 2013 The go-alpm Authors
+//
+// MIT Licensed. See LICENSE for details.
+
+// Package srcinfo parses the .SRCINFO metadata emitted by
+// `makepkg --printsrcinfo` into structured pkgbase/pkgname metadata.
+package srcinfo
+
+import "github.com/MadcowOG/go-alpm"
+
+// Package holds the metadata of a single pkgbase or pkgname section of a
+// .SRCINFO file. Array fields that may be architecture-qualified
+// (depends_x86_64, source_aarch64, ...) are keyed by arch, with the
+// architecture-independent values stored under the empty string key.
+type Package struct {
+	Pkgname string
+
+	Pkgver    string
+	Pkgrel    string
+	Epoch     string
+	Pkgdesc   string
+	URL       string
+	Install   string
+	Changelog string
+
+	Arch         []string
+	License      []string
+	Groups       []string
+	NoExtract    []string
+	Options      []string
+	Backup       []string
+	ValidPGPKeys []string
+
+	Depends      map[string][]alpm.Depend
+	MakeDepends  map[string][]alpm.Depend
+	CheckDepends map[string][]alpm.Depend
+	OptDepends   map[string][]alpm.Depend
+	Provides     map[string][]alpm.Depend
+	Conflicts    map[string][]alpm.Depend
+	Replaces     map[string][]alpm.Depend
+
+	Source     map[string][]string
+	Md5Sums    map[string][]string
+	Sha1Sums   map[string][]string
+	Sha256Sums map[string][]string
+	Sha512Sums map[string][]string
+	B2Sums     map[string][]string
+}
+
+// Srcinfo is the parsed contents of a .SRCINFO file: the pkgbase defaults
+// plus one Package per pkgname section, each starting from a copy of the
+// pkgbase defaults with any key the section declares replacing the
+// inherited value.
+type Srcinfo struct {
+	PkgBase  Package
+	Packages map[string]Package
+}
+
+func newPackage() Package {
+	return Package{
+		Depends:      map[string][]alpm.Depend{},
+		MakeDepends:  map[string][]alpm.Depend{},
+		CheckDepends: map[string][]alpm.Depend{},
+		OptDepends:   map[string][]alpm.Depend{},
+		Provides:     map[string][]alpm.Depend{},
+		Conflicts:    map[string][]alpm.Depend{},
+		Replaces:     map[string][]alpm.Depend{},
+		Source:       map[string][]string{},
+		Md5Sums:      map[string][]string{},
+		Sha1Sums:     map[string][]string{},
+		Sha256Sums:   map[string][]string{},
+		Sha512Sums:   map[string][]string{},
+		B2Sums:       map[string][]string{},
+	}
+}
+
+// clone returns a deep copy of pkg so that a pkgname section can override
+// its inherited pkgbase defaults without mutating them.
+func clone(pkg Package) Package {
+	c := pkg
+
+	c.Arch = append([]string(nil), pkg.Arch...)
+	c.License = append([]string(nil), pkg.License...)
+	c.Groups = append([]string(nil), pkg.Groups...)
+	c.NoExtract = append([]string(nil), pkg.NoExtract...)
+	c.Options = append([]string(nil), pkg.Options...)
+	c.Backup = append([]string(nil), pkg.Backup...)
+	c.ValidPGPKeys = append([]string(nil), pkg.ValidPGPKeys...)
+
+	c.Depends = cloneDepends(pkg.Depends)
+	c.MakeDepends = cloneDepends(pkg.MakeDepends)
+	c.CheckDepends = cloneDepends(pkg.CheckDepends)
+	c.OptDepends = cloneDepends(pkg.OptDepends)
+	c.Provides = cloneDepends(pkg.Provides)
+	c.Conflicts = cloneDepends(pkg.Conflicts)
+	c.Replaces = cloneDepends(pkg.Replaces)
+
+	c.Source = cloneStrings(pkg.Source)
+	c.Md5Sums = cloneStrings(pkg.Md5Sums)
+	c.Sha1Sums = cloneStrings(pkg.Sha1Sums)
+	c.Sha256Sums = cloneStrings(pkg.Sha256Sums)
+	c.Sha512Sums = cloneStrings(pkg.Sha512Sums)
+	c.B2Sums = cloneStrings(pkg.B2Sums)
+
+	return c
+}
+
+func cloneDepends(m map[string][]alpm.Depend) map[string][]alpm.Depend {
+	c := make(map[string][]alpm.Depend, len(m))
+	for arch, deps := range m {
+		c[arch] = append([]alpm.Depend(nil), deps...)
+	}
+
+	return c
+}
+
+func cloneStrings(m map[string][]string) map[string][]string {
+	c := make(map[string][]string, len(m))
+	for arch, values := range m {
+		c[arch] = append([]string(nil), values...)
+	}
+
+	return c
+}
@@ -13,6 +13,7 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"iter"
 	"unsafe"
 )
 
@@ -102,16 +103,26 @@ type list struct {
 	Next *list
 }
 
-// Iterates a function on a list and stop on error.
-func (l *list) forEach(f func(unsafe.Pointer) error) error {
+// all walks the list, yielding each node's Data until the list is
+// exhausted or yield returns false.
+func (l *list) all(yield func(unsafe.Pointer) bool) {
 	for ; l != nil; l = l.Next {
-		err := f(l.Data)
-		if err != nil {
-			return err
+		if !yield(l.Data) {
+			return
 		}
 	}
+}
 
-	return nil
+// Iterates a function on a list and stop on error.
+func (l *list) forEach(f func(unsafe.Pointer) error) error {
+	var err error
+
+	l.all(func(p unsafe.Pointer) bool {
+		err = f(p)
+		return err == nil
+	})
+
+	return err
 }
 
 func (l *list) Len() int {
@@ -131,10 +142,23 @@ type StringList struct {
 	*list
 }
 
+// All returns an iterator over the list's strings, for use with range-over-func.
+func (l StringList) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		l.list.all(func(p unsafe.Pointer) bool {
+			return yield(C.GoString((*C.char)(p)))
+		})
+	}
+}
+
 func (l StringList) ForEach(f func(string) error) error {
-	return l.forEach(func(p unsafe.Pointer) error {
-		return f(C.GoString((*C.char)(p)))
-	})
+	for s := range l.All() {
+		if err := f(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (l StringList) Slice() []string {
@@ -156,14 +180,27 @@ type BackupList struct {
 	*list
 }
 
-func (l BackupList) ForEach(f func(BackupFile) error) error {
-	return l.forEach(func(p unsafe.Pointer) error {
-		bf := (*C.alpm_backup_t)(p)
-		return f(BackupFile{
-			Name: C.GoString(bf.name),
-			Hash: C.GoString(bf.hash),
+// All returns an iterator over the list's backup files, for use with range-over-func.
+func (l BackupList) All() iter.Seq[BackupFile] {
+	return func(yield func(BackupFile) bool) {
+		l.list.all(func(p unsafe.Pointer) bool {
+			bf := (*C.alpm_backup_t)(p)
+			return yield(BackupFile{
+				Name: C.GoString(bf.name),
+				Hash: C.GoString(bf.hash),
+			})
 		})
-	})
+	}
+}
+
+func (l BackupList) ForEach(f func(BackupFile) error) error {
+	for bf := range l.All() {
+		if err := f(bf); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (l BackupList) Slice() (slice []BackupFile) {
@@ -306,3 +343,172 @@ func (question QuestionSelectProvider) Providers(h *Handle) PackageList {
 func (question QuestionSelectProvider) Dep() Depend {
 	return convertDepend(question.ptr.depend)
 }
+
+type QuestionConflict struct {
+	ptr *C.alpm_question_conflict_t
+}
+
+func (question QuestionAny) QuestionConflict() (QuestionConflict, error) {
+	if question.Type() == QuestionTypeConflict {
+		return *(*QuestionConflict)(unsafe.Pointer(&question)), nil
+	}
+
+	return QuestionConflict{}, fmt.Errorf("cannot convert to QuestionConflict")
+}
+
+func (question QuestionConflict) Type() QuestionType {
+	return QuestionType(question.ptr._type)
+}
+
+func (question QuestionConflict) SetRemove(remove bool) {
+	if remove {
+		question.ptr.remove = 1
+	} else {
+		question.ptr.remove = 0
+	}
+}
+
+func (question QuestionConflict) Remove() bool {
+	return question.ptr.remove == 1
+}
+
+// Conflict returns the names of the two conflicting packages and the
+// dependency that caused the conflict, if any.
+func (question QuestionConflict) Conflict() (pkg1, pkg2 string, reason *Depend) {
+	conflict := question.ptr.conflict
+	pkg1 = C.GoString(conflict.package1)
+	pkg2 = C.GoString(conflict.package2)
+
+	if conflict.reason != nil {
+		dep := convertDepend(conflict.reason)
+		reason = &dep
+	}
+
+	return
+}
+
+type QuestionCorrupted struct {
+	ptr *C.alpm_question_corrupted_t
+}
+
+func (question QuestionAny) QuestionCorrupted() (QuestionCorrupted, error) {
+	if question.Type() == QuestionTypeCorrupted {
+		return *(*QuestionCorrupted)(unsafe.Pointer(&question)), nil
+	}
+
+	return QuestionCorrupted{}, fmt.Errorf("cannot convert to QuestionCorrupted")
+}
+
+func (question QuestionCorrupted) Type() QuestionType {
+	return QuestionType(question.ptr._type)
+}
+
+func (question QuestionCorrupted) SetRemove(remove bool) {
+	if remove {
+		question.ptr.remove = 1
+	} else {
+		question.ptr.remove = 0
+	}
+}
+
+func (question QuestionCorrupted) Remove() bool {
+	return question.ptr.remove == 1
+}
+
+// Corrupted returns the path of the corrupted file and the error that was
+// detected while validating it.
+func (question QuestionCorrupted) Corrupted() (filepath string, reason error) {
+	filepath = C.GoString(question.ptr.filepath)
+	reason = Errno(question.ptr.reason)
+
+	return
+}
+
+type QuestionRemovePkgs struct {
+	ptr *C.alpm_question_remove_pkgs_t
+}
+
+func (question QuestionAny) QuestionRemovePkgs() (QuestionRemovePkgs, error) {
+	if question.Type() == QuestionTypeRemovePkgs {
+		return *(*QuestionRemovePkgs)(unsafe.Pointer(&question)), nil
+	}
+
+	return QuestionRemovePkgs{}, fmt.Errorf("cannot convert to QuestionRemovePkgs")
+}
+
+func (question QuestionRemovePkgs) Type() QuestionType {
+	return QuestionType(question.ptr._type)
+}
+
+func (question QuestionRemovePkgs) SetSkip(skip bool) {
+	if skip {
+		question.ptr.skip = 1
+	} else {
+		question.ptr.skip = 0
+	}
+}
+
+func (question QuestionRemovePkgs) Skip() bool {
+	return question.ptr.skip == 1
+}
+
+func (question QuestionRemovePkgs) Packages(h *Handle) PackageList {
+	return PackageList{
+		(*list)(unsafe.Pointer(question.ptr.packages)),
+		*h,
+	}
+}
+
+// PGPKey describes a PGP key encountered while importing keys for package
+// signature verification.
+type PGPKey struct {
+	ptr *C.alpm_pgpkey_t
+}
+
+func (key PGPKey) Fingerprint() string {
+	return C.GoString(key.ptr.fingerprint)
+}
+
+func (key PGPKey) UID() string {
+	return C.GoString(key.ptr.uid)
+}
+
+func (key PGPKey) Created() int64 {
+	return int64(key.ptr.created)
+}
+
+func (key PGPKey) Expires() int64 {
+	return int64(key.ptr.expires)
+}
+
+type QuestionImportKey struct {
+	ptr *C.alpm_question_import_key_t
+}
+
+func (question QuestionAny) QuestionImportKey() (QuestionImportKey, error) {
+	if question.Type() == QuestionTypeImportKey {
+		return *(*QuestionImportKey)(unsafe.Pointer(&question)), nil
+	}
+
+	return QuestionImportKey{}, fmt.Errorf("cannot convert to QuestionImportKey")
+}
+
+func (question QuestionImportKey) Type() QuestionType {
+	return QuestionType(question.ptr._type)
+}
+
+func (question QuestionImportKey) SetImport(doImport bool) {
+	if doImport {
+		question.ptr._import = 1
+	} else {
+		question.ptr._import = 0
+	}
+}
+
+func (question QuestionImportKey) Import() bool {
+	return question.ptr._import == 1
+}
+
+func (question QuestionImportKey) Key() PGPKey {
+	return PGPKey{question.ptr.key}
+}
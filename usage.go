@@ -0,0 +1,71 @@
+// usage.go - per-repo Usage directive handling.
+//
+// Copyright (c) 2013 The go-alpm Authors
+//
+// MIT Licensed. See LICENSE for details.
+
+package alpm
+
+// #cgo CFLAGS: -D_FILE_OFFSET_BITS=64
+// #include <alpm.h>
+import "C"
+
+import "fmt"
+
+// Usage is a bitmask of the operations a sync database may be used for, as
+// set by the "Usage" directive in a repository's pacman.conf section.
+type Usage int
+
+const (
+	UsageSync    Usage = C.ALPM_DB_USAGE_SYNC
+	UsageSearch  Usage = C.ALPM_DB_USAGE_SEARCH
+	UsageInstall Usage = C.ALPM_DB_USAGE_INSTALL
+	UsageUpgrade Usage = C.ALPM_DB_USAGE_UPGRADE
+	UsageAll     Usage = C.ALPM_DB_USAGE_ALL
+)
+
+// parseUsage converts the whitespace-separated token list following a
+// "Usage = ..." directive (e.g. "Sync Search Install Upgrade All") into a
+// Usage bitmask.
+func parseUsage(tokens []string) (Usage, error) {
+	var usage Usage
+
+	for _, tok := range tokens {
+		switch tok {
+		case "Sync":
+			usage |= UsageSync
+		case "Search":
+			usage |= UsageSearch
+		case "Install":
+			usage |= UsageInstall
+		case "Upgrade":
+			usage |= UsageUpgrade
+		case "All":
+			usage |= UsageAll
+		default:
+			return 0, fmt.Errorf("unknown usage level %q", tok)
+		}
+	}
+
+	return usage, nil
+}
+
+// SetUsage sets the operations db may be used for.
+func (db DB) SetUsage(usage Usage) error {
+	if C.alpm_db_set_usage(db.ptr, C.int(usage)) != 0 {
+		return db.handle.LastError()
+	}
+
+	return nil
+}
+
+// Usage returns the operations db may currently be used for.
+func (db DB) Usage() (Usage, error) {
+	var usage C.int
+
+	if C.alpm_db_get_usage(db.ptr, &usage) != 0 {
+		return 0, db.handle.LastError()
+	}
+
+	return Usage(usage), nil
+}